@@ -0,0 +1,145 @@
+package common
+
+import (
+	"context"
+	"sync"
+)
+
+// SendCtx sends elem on ch, blocking until the element is delivered or ctx is
+// done.
+//
+// Parameters:
+//   - ctx: The context governing the send. Must not be nil.
+//   - ch: The channel to send on. A nil channel blocks until ctx is done.
+//   - elem: The element to send.
+//
+// Returns:
+//   - error: The context's error if it was done before the element could be
+//     delivered, nil otherwise.
+//
+// Unlike a non-blocking send, an element is never silently dropped: either it
+// is received or the caller is told why it was not.
+func SendCtx[T any](ctx context.Context, ch chan<- T, elem T) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	select {
+	case ch <- elem:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// ReceiveCtx receives an element from ch, blocking until one is available, the
+// channel is closed, or ctx is done.
+//
+// Parameters:
+//   - ctx: The context governing the receive. Must not be nil.
+//   - ch: The channel to receive from. A nil channel blocks until ctx is done.
+//
+// Returns:
+//   - T: The received element, or the zero value on error.
+//   - error: The context's error if it was done first, ErrClosedChannel if ch
+//     was closed, nil otherwise.
+func ReceiveCtx[T any](ctx context.Context, ch <-chan T) (T, error) {
+	var zero T
+
+	if err := ctx.Err(); err != nil {
+		return zero, err
+	}
+
+	select {
+	case elem, ok := <-ch:
+		if !ok {
+			return zero, ErrClosedChannel
+		}
+
+		return elem, nil
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// FanIn merges several channels into a single one. The returned channel is
+// closed once every input channel has been closed.
+//
+// Parameters:
+//   - chs: The channels to merge. Nil channels are ignored.
+//
+// Returns:
+//   - <-chan T: The merged channel. Never returns nil.
+//
+// The relative order of elements coming from different inputs is not
+// specified. The returned channel must be drained until closed; otherwise the
+// forwarding goroutines are leaked.
+func FanIn[T any](chs ...<-chan T) <-chan T {
+	out := make(chan T)
+
+	var wg sync.WaitGroup
+
+	for _, ch := range chs {
+		if ch == nil {
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(ch <-chan T) {
+			defer wg.Done()
+
+			for elem := range ch {
+				out <- elem
+			}
+		}(ch)
+	}
+
+	go func() {
+		wg.Wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// FanOut distributes the elements of a channel across n channels. Each
+// element is delivered to exactly one of the outputs. Every output is closed
+// once ch has been closed and drained.
+//
+// Parameters:
+//   - ch: The channel to distribute.
+//   - n: The number of output channels.
+//
+// Returns:
+//   - []<-chan T: The n output channels. Nil if ch is nil or n is not
+//     positive.
+//
+// Each output is fed by its own goroutine, which takes the next element from
+// ch and holds it until the output's reader receives it. Every output can
+// therefore take one element ahead of its reader, and a slow reader keeps that
+// element even while the other outputs are idle. Every output must be drained
+// until closed; an output that is left unread pins its element and leaks its
+// goroutine.
+func FanOut[T any](ch <-chan T, n int) []<-chan T {
+	if ch == nil || n <= 0 {
+		return nil
+	}
+
+	outs := make([]<-chan T, 0, n)
+
+	for range n {
+		out := make(chan T)
+		outs = append(outs, out)
+
+		go func() {
+			defer close(out)
+
+			for elem := range ch {
+				out <- elem
+			}
+		}()
+	}
+
+	return outs
+}
@@ -0,0 +1,287 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+// blockWait is how long a test lets a call block before acting on it.
+const blockWait = 20 * time.Millisecond
+
+func TestSendCtx(t *testing.T) {
+	t.Run("delivers on unbuffered channel", func(t *testing.T) {
+		ch := make(chan int)
+		done := make(chan error, 1)
+
+		go func() {
+			done <- SendCtx(context.Background(), ch, 42)
+		}()
+
+		if got := <-ch; got != 42 {
+			t.Fatalf("want 42, got %d", got)
+		}
+
+		if err := <-done; err != nil {
+			t.Fatalf("want nil error, got %v", err)
+		}
+	})
+
+	t.Run("cancelled before send", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		ch := make(chan int, 1)
+
+		err := SendCtx(ctx, ch, 1)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("want %v, got %v", context.Canceled, err)
+		}
+
+		if len(ch) != 0 {
+			t.Fatalf("element was sent despite cancelled context")
+		}
+	})
+
+	t.Run("cancelled while blocked", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := make(chan int)
+		done := make(chan error, 1)
+
+		go func() {
+			done <- SendCtx(ctx, ch, 1)
+		}()
+
+		time.Sleep(blockWait)
+		cancel()
+
+		if err := <-done; !errors.Is(err, context.Canceled) {
+			t.Fatalf("want %v, got %v", context.Canceled, err)
+		}
+	})
+}
+
+func TestReceiveCtx(t *testing.T) {
+	t.Run("receives on unbuffered channel", func(t *testing.T) {
+		ch := make(chan int)
+
+		go func() {
+			ch <- 42
+		}()
+
+		got, err := ReceiveCtx(context.Background(), ch)
+		if err != nil {
+			t.Fatalf("want nil error, got %v", err)
+		}
+
+		if got != 42 {
+			t.Fatalf("want 42, got %d", got)
+		}
+	})
+
+	t.Run("cancelled before receive", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		ch := make(chan int, 1)
+		ch <- 1
+
+		_, err := ReceiveCtx(ctx, ch)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("want %v, got %v", context.Canceled, err)
+		}
+
+		if len(ch) != 1 {
+			t.Fatalf("element was consumed despite cancelled context")
+		}
+	})
+
+	t.Run("cancelled while blocked", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		ch := make(chan int)
+		done := make(chan error, 1)
+
+		go func() {
+			_, err := ReceiveCtx(ctx, ch)
+			done <- err
+		}()
+
+		time.Sleep(blockWait)
+		cancel()
+
+		if err := <-done; !errors.Is(err, context.Canceled) {
+			t.Fatalf("want %v, got %v", context.Canceled, err)
+		}
+	})
+
+	t.Run("closed channel", func(t *testing.T) {
+		ch := make(chan int)
+		close(ch)
+
+		got, err := ReceiveCtx(context.Background(), ch)
+		if !errors.Is(err, ErrClosedChannel) {
+			t.Fatalf("want %v, got %v", ErrClosedChannel, err)
+		}
+
+		if got != 0 {
+			t.Fatalf("want zero value, got %d", got)
+		}
+	})
+}
+
+// produce returns a channel that yields from, from+1, ..., from+n-1 and is
+// then closed.
+func produce(from, n int) <-chan int {
+	ch := make(chan int)
+
+	go func() {
+		defer close(ch)
+
+		for i := range n {
+			ch <- from + i
+		}
+	}()
+
+	return ch
+}
+
+// collect drains ch until it is closed, failing the test if it does not close
+// within a second. It is safe to call from any goroutine.
+func collect(t *testing.T, ch <-chan int) []int {
+	t.Helper()
+
+	var elems []int
+
+	timeout := time.After(time.Second)
+
+	for {
+		select {
+		case elem, ok := <-ch:
+			if !ok {
+				return elems
+			}
+
+			elems = append(elems, elem)
+		case <-timeout:
+			t.Errorf("channel was not closed")
+
+			return elems
+		}
+	}
+}
+
+// checkOnce fails the test unless elems holds every value of [0, n) exactly
+// once.
+func checkOnce(t *testing.T, elems []int, n int) {
+	t.Helper()
+
+	if len(elems) != n {
+		t.Fatalf("want %d elements, got %d", n, len(elems))
+	}
+
+	seen := make([]bool, n)
+
+	for _, elem := range elems {
+		if elem < 0 || elem >= n {
+			t.Fatalf("unexpected element %d", elem)
+		}
+
+		if seen[elem] {
+			t.Fatalf("element %d delivered more than once", elem)
+		}
+
+		seen[elem] = true
+	}
+}
+
+func TestFanIn(t *testing.T) {
+	t.Run("merges and closes", func(t *testing.T) {
+		out := FanIn(produce(0, 50), produce(50, 30), produce(80, 20))
+
+		checkOnce(t, collect(t, out), 100)
+	})
+
+	t.Run("waits for every input", func(t *testing.T) {
+		open := make(chan int)
+		out := FanIn(produce(0, 3), (<-chan int)(open))
+
+		for range 3 {
+			<-out
+		}
+
+		select {
+		case _, ok := <-out:
+			t.Fatalf("output yielded (ok=%t) before every input was closed", ok)
+		case <-time.After(blockWait):
+		}
+
+		close(open)
+
+		if elems := collect(t, out); len(elems) != 0 {
+			t.Fatalf("want no more elements, got %v", elems)
+		}
+	})
+
+	t.Run("no inputs", func(t *testing.T) {
+		if elems := collect(t, FanIn[int]()); len(elems) != 0 {
+			t.Fatalf("want no elements, got %v", elems)
+		}
+	})
+
+	t.Run("nil inputs", func(t *testing.T) {
+		out := FanIn(nil, produce(0, 10), nil)
+
+		checkOnce(t, collect(t, out), 10)
+	})
+}
+
+func TestFanOut(t *testing.T) {
+	t.Run("delivers once and closes every output", func(t *testing.T) {
+		const n = 200
+
+		outs := FanOut(produce(0, n), 4)
+		if len(outs) != 4 {
+			t.Fatalf("want 4 outputs, got %d", len(outs))
+		}
+
+		var (
+			mu    sync.Mutex
+			elems []int
+			wg    sync.WaitGroup
+		)
+
+		for _, out := range outs {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				got := collect(t, out)
+
+				mu.Lock()
+				elems = append(elems, got...)
+				mu.Unlock()
+			}()
+		}
+
+		wg.Wait()
+
+		checkOnce(t, elems, n)
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		if outs := FanOut(produce(0, 0), 0); outs != nil {
+			t.Fatalf("want nil for n = 0, got %v", outs)
+		}
+
+		if outs := FanOut(produce(0, 0), -1); outs != nil {
+			t.Fatalf("want nil for n < 0, got %v", outs)
+		}
+
+		if outs := FanOut[int](nil, 2); outs != nil {
+			t.Fatalf("want nil for nil channel, got %v", outs)
+		}
+	})
+}
@@ -0,0 +1,3 @@
+// Package common contains small, dependency-free helpers shared by the
+// evaluation packages of this module.
+package common
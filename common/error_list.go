@@ -0,0 +1,189 @@
+package common
+
+import (
+	"strconv"
+	"strings"
+)
+
+// ErrorList is an error that joins several errors, dropping those whose
+// message was already recorded. The zero value is an empty list ready to use.
+//
+// Unlike errors.Join, repeated failures such as "expected X, got Y" are kept
+// only once and the rendered message can be capped with MaxShown.
+//
+// An ErrorList is not safe for concurrent use. ErrOrNil and JoinUnique return
+// a copy, so adding to the list afterwards does not alter errors already
+// handed out.
+type ErrorList struct {
+	// MaxShown is the maximum number of errors rendered by Error. Zero or a
+	// negative value means no limit.
+	MaxShown int
+
+	// errs is the list of unique errors, in insertion order.
+	errs []error
+
+	// seen is the set of messages already recorded.
+	seen map[string]struct{}
+}
+
+// NewErrorList creates a new ErrorList containing the given errors.
+//
+// Parameters:
+//   - maxShown: The maximum number of errors rendered by Error. Zero or a
+//     negative value means no limit.
+//   - errs: The errors to add. Nil and duplicate errors are ignored.
+//
+// Returns:
+//   - *ErrorList: The new error list. Never returns nil.
+func NewErrorList(maxShown int, errs ...error) *ErrorList {
+	el := &ErrorList{
+		MaxShown: maxShown,
+	}
+
+	el.Add(errs...)
+
+	return el
+}
+
+// JoinUnique is like errors.Join but discards duplicate errors.
+//
+// Parameters:
+//   - errs: The errors to join. Nil and duplicate errors are ignored.
+//
+// Returns:
+//   - error: A *ErrorList holding the unique errors, or nil if there are none.
+func JoinUnique(errs ...error) error {
+	return NewErrorList(0, errs...).ErrOrNil()
+}
+
+// Add appends errors to the list. Two errors are considered identical when
+// their messages are equal; only the first one is kept. Errors that are
+// themselves *ErrorList are flattened.
+//
+// Parameters:
+//   - errs: The errors to add. Nil errors are ignored.
+func (el *ErrorList) Add(errs ...error) {
+	if el == nil {
+		return
+	}
+
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		if other, ok := err.(*ErrorList); ok {
+			if other != nil && other != el {
+				el.Add(other.errs...)
+			}
+
+			continue
+		}
+
+		msg := err.Error()
+
+		if _, ok := el.seen[msg]; ok {
+			continue
+		}
+
+		if el.seen == nil {
+			el.seen = make(map[string]struct{})
+		}
+
+		el.seen[msg] = struct{}{}
+		el.errs = append(el.errs, err)
+	}
+}
+
+// Len returns the number of unique errors in the list.
+//
+// Returns:
+//   - int: The number of errors. Zero if the receiver is nil.
+func (el *ErrorList) Len() int {
+	if el == nil {
+		return 0
+	}
+
+	return len(el.errs)
+}
+
+// ErrOrNil returns a copy of the list as an error, or nil if it is empty. Use
+// it to avoid returning a non-nil error interface wrapping an empty list.
+//
+// Returns:
+//   - error: A *ErrorList copy of the receiver, or nil if it holds no error.
+//
+// Later calls to Add on the receiver do not affect the returned error.
+func (el *ErrorList) ErrOrNil() error {
+	if el.Len() == 0 {
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(el.seen))
+	for msg := range el.seen {
+		seen[msg] = struct{}{}
+	}
+
+	return &ErrorList{
+		MaxShown: el.MaxShown,
+		errs:     el.Unwrap(),
+		seen:     seen,
+	}
+}
+
+// Error implements the error interface.
+//
+// Format:
+//
+//	"<err1>\n<err2>\n...\n... and <n> more errors"
+//
+// Each error is on its own line. The trailing line only appears when more than
+// MaxShown errors are recorded.
+func (el *ErrorList) Error() string {
+	if el.Len() == 0 {
+		return ""
+	}
+
+	shown := el.errs
+	if el.MaxShown > 0 && len(shown) > el.MaxShown {
+		shown = shown[:el.MaxShown]
+	}
+
+	var builder strings.Builder
+
+	builder.WriteString(shown[0].Error())
+
+	for _, err := range shown[1:] {
+		builder.WriteRune('\n')
+		builder.WriteString(err.Error())
+	}
+
+	if rest := len(el.errs) - len(shown); rest > 0 {
+		builder.WriteString("\n... and ")
+		builder.WriteString(strconv.Itoa(rest))
+
+		if rest == 1 {
+			builder.WriteString(" more error")
+		} else {
+			builder.WriteString(" more errors")
+		}
+	}
+
+	return builder.String()
+}
+
+// Unwrap returns a copy of the unique errors so that errors.Is and errors.As
+// inspect every one of them.
+//
+// Returns:
+//   - []error: The unique errors, in insertion order. Nil if there are none.
+func (el *ErrorList) Unwrap() []error {
+	if el.Len() == 0 {
+		return nil
+	}
+
+	errs := make([]error, len(el.errs))
+	copy(errs, el.errs)
+
+	return errs
+}
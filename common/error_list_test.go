@@ -0,0 +1,119 @@
+package common
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestErrorList(t *testing.T) {
+	t.Run("deduplicates by message", func(t *testing.T) {
+		el := NewErrorList(0,
+			errors.New("expected X, got Y"),
+			nil,
+			errors.New("expected X, got Y"),
+			errors.New("expected Z, got Y"),
+		)
+
+		if got := el.Len(); got != 2 {
+			t.Fatalf("want 2 errors, got %d", got)
+		}
+
+		const want = "expected X, got Y\nexpected Z, got Y"
+		if got := el.Error(); got != want {
+			t.Fatalf("want %q, got %q", want, got)
+		}
+	})
+
+	t.Run("flattens nested lists", func(t *testing.T) {
+		a := errors.New("a")
+		b := errors.New("b")
+
+		inner := NewErrorList(0, a, b)
+		outer := NewErrorList(0, a, inner, errors.New("c"))
+
+		if got := outer.Len(); got != 3 {
+			t.Fatalf("want 3 errors, got %d", got)
+		}
+
+		for _, err := range outer.Unwrap() {
+			if _, ok := err.(*ErrorList); ok {
+				t.Fatalf("nested *ErrorList was not flattened")
+			}
+		}
+	})
+
+	t.Run("caps rendered errors", func(t *testing.T) {
+		tests := map[string]struct {
+			errs []error
+			want string
+		}{
+			"singular": {
+				errs: []error{errors.New("a"), errors.New("b"), errors.New("c")},
+				want: "a\nb\n... and 1 more error",
+			},
+			"plural": {
+				errs: []error{errors.New("a"), errors.New("b"), errors.New("c"), errors.New("d")},
+				want: "a\nb\n... and 2 more errors",
+			},
+			"within cap": {
+				errs: []error{errors.New("a"), errors.New("b")},
+				want: "a\nb",
+			},
+		}
+
+		for name, tt := range tests {
+			t.Run(name, func(t *testing.T) {
+				el := NewErrorList(2, tt.errs...)
+
+				if got := el.Error(); got != tt.want {
+					t.Fatalf("want %q, got %q", tt.want, got)
+				}
+
+				if got := el.Len(); got != len(tt.errs) {
+					t.Fatalf("cap dropped errors: want %d, got %d", len(tt.errs), got)
+				}
+			})
+		}
+	})
+
+	t.Run("unwrap supports errors.Is and errors.As", func(t *testing.T) {
+		sentinel := errors.New("sentinel")
+		bad := NewErrBadParam("limit", "must be positive", -1)
+
+		err := JoinUnique(errors.New("other"), sentinel, bad)
+
+		if !errors.Is(err, sentinel) {
+			t.Fatalf("errors.Is did not find sentinel in %v", err)
+		}
+
+		var target *ErrBadParam
+		if !errors.As(err, &target) {
+			t.Fatalf("errors.As did not find *ErrBadParam in %v", err)
+		}
+
+		if target != bad {
+			t.Fatalf("errors.As returned a different *ErrBadParam")
+		}
+	})
+
+	t.Run("ErrOrNil is frozen", func(t *testing.T) {
+		el := NewErrorList(0, errors.New("a"))
+
+		err := el.ErrOrNil()
+		el.Add(errors.New("b"))
+
+		if got := err.Error(); got != "a" {
+			t.Fatalf("returned error changed after Add: %q", got)
+		}
+	})
+
+	t.Run("JoinUnique returns nil", func(t *testing.T) {
+		if err := JoinUnique(); err != nil {
+			t.Fatalf("want nil for no errors, got %v", err)
+		}
+
+		if err := JoinUnique(nil, nil); err != nil {
+			t.Fatalf("want nil for nil errors, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrClosedChannel occurs when receiving from a channel that has been closed.
+//
+// Format:
+//
+//	"channel is closed"
+var ErrClosedChannel = errors.New("channel is closed")
+
+// ErrBadParam occurs when a parameter is present but has an invalid value.
+// Only *ErrBadParam implements error; retrieve it with errors.As using a
+// *ErrBadParam target.
+type ErrBadParam struct {
+	// Name is the name of the offending parameter.
+	Name string
+
+	// Reason explains why the value is invalid.
+	Reason string
+
+	// Got is the value that was received.
+	Got any
+}
+
+// Error implements the error interface.
+//
+// Format:
+//
+//	"parameter (<name>) <reason>; got <got>"
+//
+// The reason defaults to "is invalid" when empty.
+func (e *ErrBadParam) Error() string {
+	reason := e.Reason
+	if reason == "" {
+		reason = "is invalid"
+	}
+
+	return fmt.Sprintf("parameter (%s) %s; got %v", e.Name, reason, e.Got)
+}
+
+// NewErrBadParam creates a new ErrBadParam error.
+//
+// Parameters:
+//   - name: The name of the offending parameter.
+//   - reason: Why the value is invalid, e.g. "must be positive".
+//   - got: The value that was received.
+//
+// Returns:
+//   - *ErrBadParam: The new error. Never returns nil.
+func NewErrBadParam(name, reason string, got any) *ErrBadParam {
+	return &ErrBadParam{
+		Name:   name,
+		Reason: reason,
+		Got:    got,
+	}
+}
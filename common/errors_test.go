@@ -0,0 +1,49 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrBadParam(t *testing.T) {
+	t.Run("message", func(t *testing.T) {
+		err := NewErrBadParam("limit", "must be positive", -1)
+
+		const want = "parameter (limit) must be positive; got -1"
+		if got := err.Error(); got != want {
+			t.Fatalf("want %q, got %q", want, got)
+		}
+	})
+
+	t.Run("default reason", func(t *testing.T) {
+		err := &ErrBadParam{Name: "limit", Got: 0}
+
+		const want = "parameter (limit) is invalid; got 0"
+		if got := err.Error(); got != want {
+			t.Fatalf("want %q, got %q", want, got)
+		}
+	})
+
+	t.Run("errors.As through wrapping", func(t *testing.T) {
+		tests := map[string]error{
+			"constructor": NewErrBadParam("limit", "must be positive", -1),
+			"literal":     &ErrBadParam{Name: "limit", Reason: "must be positive", Got: -1},
+		}
+
+		for name, inner := range tests {
+			t.Run(name, func(t *testing.T) {
+				err := fmt.Errorf("outer: %w", fmt.Errorf("inner: %w", inner))
+
+				var target *ErrBadParam
+				if !errors.As(err, &target) {
+					t.Fatalf("errors.As did not find *ErrBadParam in %v", err)
+				}
+
+				if target.Name != "limit" || target.Reason != "must be positive" || target.Got != -1 {
+					t.Fatalf("unexpected target: %+v", *target)
+				}
+			})
+		}
+	})
+}